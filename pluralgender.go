@@ -6,15 +6,79 @@ import (
 	"fmt"
 	"github.com/fabdem/go-vdfloc/config"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 )
 
-// type t_PluralGender struct {
-// 	suffix	string
-// 	check	interface{}
-// 	}
+// TokenSuffixCheckFunc validates the value of a token carrying a given
+// plural/gender suffix (e.g. ":p", ":np", or a project-specific one added
+// via RegisterTokenSuffix).
+//	Input:
+//		- token name
+//		- token value
+//		- Language name
+//	Output:
+//		- issue == "" if no syntax issue
+//		- err
+type TokenSuffixCheckFunc func(key, val, lang string) (issue string, err error)
+
+// instanceState is one VDFFile's per-instance options: its table of
+// token-suffix check functions (plus the regexp derived from its keys, see
+// buildSuffixRegexp) and its SetGenderOrderStrict override. It belongs on
+// the VDFFile struct itself, but that struct lives outside this file, so
+// it's kept here and indexed by instance pointer instead - see
+// (*VDFFile).state. Entries are never evicted: a map keyed by *VDFFile holds
+// a strong reference to that VDFFile, so nothing ever becomes unreachable
+// while its entry exists, and there is no safe hook (short of a field on the
+// struct) to run cleanup when a VDFFile is done being used. For the
+// lifetime of a typical process (one instanceState per .vdf file processed)
+// this is an acceptable trade of memory for correctness.
+type instanceState struct {
+	mu       sync.RWMutex
+	suffixes map[string]TokenSuffixCheckFunc
+	re       *regexp.Regexp
+
+	genderOrderStrictSet bool // true once SetGenderOrderStrict has been called
+	genderOrderStrictVal bool
+}
 
-var m_pluralGender map[string]interface{}
+var instancesMu sync.RWMutex
+var instances = map[*VDFFile]*instanceState{}
+
+// defaultSuffixes seeds a new instance's registry with the suffixes the
+// library ships with.
+func defaultSuffixes() map[string]TokenSuffixCheckFunc {
+	return map[string]TokenSuffixCheckFunc{
+		":p":  checkPlural,             // plural
+		":n":  checkGenderSender,       // gender sender
+		":np": checkGenderSenderPlural, // gender sender with plural
+	}
+}
+
+// state returns v's own per-instance options, creating and seeding them with
+// the built-in defaults on first use.
+func (v *VDFFile) state() *instanceState {
+	instancesMu.RLock()
+	st, ok := instances[v]
+	instancesMu.RUnlock()
+	if ok {
+		return st
+	}
+
+	instancesMu.Lock()
+	defer instancesMu.Unlock()
+	if st, ok = instances[v]; ok { // re-check: lost the race to another caller
+		return st
+	}
+
+	st = &instanceState{suffixes: defaultSuffixes()}
+	st.suffixes[":g"] = func(k, val, lang string) (string, error) { return checkGenderReceiver(k, val, lang, v) }
+	st.suffixes[":gp"] = func(k, val, lang string) (string, error) { return checkGenderReceiverPlural(k, val, lang, v) }
+	st.re = buildSuffixRegexp(st.suffixes)
+	instances[v] = st
+	return st
+}
 
 // var suffixesPluralGender []string
 var pluralTag string
@@ -26,15 +90,6 @@ const defaultJson = "pluralgender.json" // located along with the exe or bin
 
 func init() {
 
-	// Defines each token suffixe and its associated check function
-	m_pluralGender = map[string]interface{}{
-		":p":  checkPlural,               // plural
-		":n":  checkGenderSender,         // gender sender
-		":g":  checkGenderReceiver,       // gender receiver
-		":np": checkGenderSenderPlural,   // gender sender with plural
-		":gp": checkGenderReceiverPlural, // gender receiver with plural
-	}
-
 	genderTags = []string{
 		"#|f|#",
 		"#|n|#",
@@ -69,9 +124,269 @@ func LoadJsonConf(f string) (err error) {
 	return err
 }
 
+// buildSuffixRegexp()
+//
+// Build the regular expression used to capture a token suffix at the end of
+// a token name, out of the keys of byName. Longer suffixes are tried first
+// (e.g. ":np" before ":n") so that a shorter registered suffix can't shadow
+// one it's a prefix of.
+// 	Input:
+//		- byName: the suffix table to build the regexp from
+// 	Output:
+//		- compiled regexp
+//
+func buildSuffixRegexp(byName map[string]TokenSuffixCheckFunc) *regexp.Regexp {
+	suffixes := make([]string, 0, len(byName))
+	for sufx := range byName {
+		suffixes = append(suffixes, regexp.QuoteMeta(sufx))
+	}
+	sort.Slice(suffixes, func(i, j int) bool { return len(suffixes[i]) > len(suffixes[j]) })
+
+	return regexp.MustCompile(`(` + strings.Join(suffixes, "|") + `)(?:\{[a-zA-Z_\d:]+\})?$`)
+}
+
+// RegisterTokenSuffix()
+//
+// Register (or replace), for this VDFFile instance only, the check function
+// associated with a token suffix, e.g. ":case" or ":politeness" for
+// game-specific tokens that don't ship with the library. Once registered the
+// suffix is recognised by this instance's CheckPlrlGendrTokenVal and
+// FilterPlrGdr exactly like the built-in ones (:p, :n, :g, :np, :gp).
+// 	Input:
+//		- suffix: token suffix including the leading ':'
+//		- fn: function called to check the syntax of a token value carrying that suffix
+// 	Output:
+//		- err != nil if the suffix or the check function is invalid
+//
+func (v *VDFFile) RegisterTokenSuffix(suffix string, fn TokenSuffixCheckFunc) (err error) {
+	if !strings.HasPrefix(suffix, ":") || len(suffix) < 2 {
+		return fmt.Errorf("invalid token suffix: %q - must start with ':'", suffix)
+	}
+	if fn == nil {
+		return fmt.Errorf("invalid (nil) check function for token suffix: %q", suffix)
+	}
+
+	st := v.state()
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.suffixes[suffix] = fn
+	st.re = buildSuffixRegexp(st.suffixes)
+
+	return nil
+}
+
+// UnregisterTokenSuffix()
+//
+// Remove a previously registered token suffix, built-in or project-specific,
+// from this VDFFile instance. No-op if the suffix isn't registered.
+// 	Input:
+//		- suffix: token suffix including the leading ':'
+//
+func (v *VDFFile) UnregisterTokenSuffix(suffix string) {
+	st := v.state()
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	delete(st.suffixes, suffix)
+	st.re = buildSuffixRegexp(st.suffixes)
+}
+
+// pluralFormCount()
+//
+// Number of plural forms expected for a language. Uses the CLDR category
+// table when the config declares one (config.GetPluralCategories), and
+// falls back to the legacy single integer form (config.GetPlural) otherwise.
+// 	Input:
+//		- Language name
+// 	Output:
+//		- number of plural forms
+//		- err
+//
+func pluralFormCount(lang string) (n int, err error) {
+	if cats, cerr := conf.GetPluralCategories(lang); cerr == nil && len(cats) > 0 {
+		return len(cats), nil
+	}
+	return conf.GetPlural(lang)
+}
+
+// cldrCategoryTag captures an explicit CLDR plural category marker, e.g. "#|one|#".
+var cldrCategoryTag = regexp.MustCompile(`#\|(zero|one|two|few|many|other)\|#`)
+
+// checkPluralCLDR()
+//
+// Check plural syntax against a language's CLDR category table. If the
+// token value embeds explicit category markers (e.g. "#|one|# ... #|other|#")
+// they must match the expected categories exactly, in order. Otherwise fall
+// back to counting plain plural-tag ("#|#") separators against the number
+// of expected categories, same as the legacy integer form.
+// 	Input:
+//		- token value
+//		- ordered list of CLDR categories expected for the language
+// 	Output:
+//		- issue == nil if no syntax issue
+//		- err
+//
+func checkPluralCLDR(v string, categories []string) (res string, err error) {
+	found := cldrCategoryTag.FindAllStringSubmatch(v, -1)
+
+	if len(found) == 0 {
+		if ct := strings.Count(v, pluralTag); ct != len(categories)-1 {
+			res = formatIssue(ErrPluralCount, len(categories), ct+1)
+		}
+		return res, err
+	}
+
+	if len(found) != len(categories) {
+		res = formatIssue(ErrPluralCategoryCount, len(categories), strings.Join(categories, ", "), len(found))
+		return res, err
+	}
+
+	for i, m := range found {
+		if m[1] != categories[i] {
+			res = formatIssue(ErrPluralCategoryOrder, categories[i], i+1, m[1])
+			return res, err
+		}
+	}
+
+	return res, err
+}
+
+// Error codes keying the message catalogue, passed to formatIssue() instead
+// of building the `res` string inline. Keeping them stable lets a caller
+// render its own reviewer-facing wording via SetMessage without depending on
+// the English text.
+const (
+	ErrGenderCount            = "ERR_GENDER_COUNT"
+	ErrGenderOrder            = "ERR_GENDER_ORDER"
+	ErrGenderTag              = "ERR_GENDER_TAG"
+	ErrGenderUnexpected       = "ERR_GENDER_UNEXPECTED"
+	ErrPluralCount            = "ERR_PLURAL_COUNT"
+	ErrPluralCategoryCount    = "ERR_PLURAL_CATEGORY_COUNT"
+	ErrPluralCategoryOrder    = "ERR_PLURAL_CATEGORY_ORDER"
+	ErrGenderPluralSeparator  = "ERR_GENDER_PLURAL_SEPARATOR"
+	ErrGenderPluralTag        = "ERR_GENDER_PLURAL_TAG"
+	ErrGenderPluralCount      = "ERR_GENDER_PLURAL_COUNT"
+	ErrGenderPluralTagCount   = "ERR_GENDER_PLURAL_TAG_COUNT"
+	ErrGenderPluralUnexpected = "ERR_GENDER_PLURAL_UNEXPECTED"
+	ErrGenderPluralOrder      = "ERR_GENDER_PLURAL_ORDER"
+)
+
+var messageCatalogueMu sync.RWMutex
+
+// messageCatalogue maps an error code to the fmt format string used to
+// render it. English is the built-in default; replace an entry with
+// SetMessage to render reviewer-facing diagnostics in the translator's
+// language instead.
+var messageCatalogue = map[string]string{
+	ErrGenderCount:            "Error with gender form - expected %s",
+	ErrGenderOrder:            "Error with gender form - the first gender tag should be at the begining of the string. Found at position %d",
+	ErrGenderTag:              "Error with gender form: %s - expected: %s",
+	ErrGenderUnexpected:       "Error with gender form: %s - no gender expected",
+	ErrPluralCount:            "Expected number of plural forms: %d - found: %d",
+	ErrPluralCategoryCount:    "Expected %d plural categories (%s) - found %d",
+	ErrPluralCategoryOrder:    "expected category `%s` at position %d, found `%s`",
+	ErrGenderPluralSeparator:  "Error with gender/plural form: found %d plural forms, while expecting %d separated wiht a  plural tag.",
+	ErrGenderPluralTag:        "Error with gender/plural form: this tag was unexpected %s",
+	ErrGenderPluralCount:      "Error with gender/plural forms - counted %d while expecting %d",
+	ErrGenderPluralTagCount:   "Error with gender/plural form: %s - found %d plural forms while expecting %d of each gender group: %s",
+	ErrGenderPluralUnexpected: "Error with gender/plural form: %s - no gender expected",
+	ErrGenderPluralOrder:      "Error with gender/plural form: incorrect order plural form: %d, gender tag: %s",
+}
+
+// formatIssue()
+//
+// Render an issue string for an error code using the message catalogue.
+// Falls back to the code itself if it isn't in the catalogue.
+//
+func formatIssue(code string, args ...interface{}) string {
+	messageCatalogueMu.RLock()
+	format, ok := messageCatalogue[code]
+	messageCatalogueMu.RUnlock()
+
+	if !ok {
+		format = code
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// SetMessage()
+//
+// Replace (or add) a message catalogue entry, so the issue strings returned
+// by the check functions can be rendered in the translator's own language.
+// 	Input:
+//		- code: one of the ERR_* constants
+//		- format: fmt format string, same verbs/order as the built-in entry for that code
+//
+func (v *VDFFile) SetMessage(code string, format string) {
+	messageCatalogueMu.Lock()
+	defer messageCatalogueMu.Unlock()
+
+	messageCatalogue[code] = format
+}
+
+// SetGenderOrderStrict()
+//
+// Force, for this VDFFile instance, whether checkGenderReceiver requires the
+// first gender tag to sit at the beginning of the value - and whether
+// checkGenderReceiverPlural requires each plural block's gender tags to
+// appear in that same fixed order. Overrides the per-language
+// `strictGenderOrder` config flag. Translators frequently reorder gender
+// tags for grammatical reasons (e.g. Spanish adjective placement), so
+// relaxing this only checks that one of each expected tag is present,
+// regardless of position (within a plural block, for the plural case).
+// 	Input:
+//		- strict: false to accept gender tags in any position/order
+//
+func (v *VDFFile) SetGenderOrderStrict(strict bool) {
+	st := v.state()
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.genderOrderStrictSet = true
+	st.genderOrderStrictVal = strict
+}
+
+// ResetGenderOrderStrict()
+//
+// Clear a previous SetGenderOrderStrict call on this VDFFile instance so the
+// per-language `strictGenderOrder` config flag is consulted again.
+//
+func (v *VDFFile) ResetGenderOrderStrict() {
+	st := v.state()
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.genderOrderStrictSet = false
+}
+
+// genderOrderStrict reports whether lang requires gender tags in a fixed
+// order/position, for this VDFFile instance. Defers to the config's
+// strictGenderOrder flag unless overridden via SetGenderOrderStrict;
+// defaults to strict (the historical behavior) if the config doesn't
+// declare the flag.
+func (v *VDFFile) genderOrderStrict(lang string) bool {
+	st := v.state()
+	st.mu.RLock()
+	set, val := st.genderOrderStrictSet, st.genderOrderStrictVal
+	st.mu.RUnlock()
+	if set {
+		return val
+	}
+
+	strict, err := conf.GetStrictGenderOrder(lang)
+	if err != nil {
+		return true
+	}
+	return strict
+}
+
 // checkPlural()
 //
-// Check plural syntax in a token value.
+// Check plural syntax in a token value. Uses CLDR category validation when
+// the config declares a category table for the language (see
+// checkPluralCLDR), otherwise falls back to counting plural forms against
+// the legacy integer form.
 // 	Input:
 //		- token name
 //		- token value
@@ -81,6 +396,10 @@ func LoadJsonConf(f string) (err error) {
 //		- err
 //
 func checkPlural(k string, v string, lang string) (res string, err error) {
+	if cats, cerr := conf.GetPluralCategories(lang); cerr == nil && len(cats) > 0 {
+		return checkPluralCLDR(v, cats)
+	}
+
 	n, err := conf.GetPlural(lang)
 	if err != nil {
 		return res, err
@@ -91,7 +410,7 @@ func checkPlural(k string, v string, lang string) (res string, err error) {
 	} // e.g. 2 form plural -> 1 separator
 
 	if ct := strings.Count(v, pluralTag); ct != n {
-		res = fmt.Sprintf("Expected number of plural forms: %d - found: %d", n+1, ct+1)
+		res = formatIssue(ErrPluralCount, n+1, ct+1)
 	}
 	return res, err
 }
@@ -126,9 +445,9 @@ func checkGenderSender(k string, v string, lang string) (res string, err error)
 
 		if ok := strings.Contains(list, gender); (ct > 1) || (ct == 1 && !ok) { // bad syntax cases
 			if len(list) > 0 {
-				res = fmt.Sprintf("Error with gender form: %s - expected only one of: %s", gender, list)
+				res = formatIssue(ErrGenderTag, gender, list)
 			} else {
-				res = fmt.Sprintf("Error with gender form: %s - no gender expected", gender)
+				res = formatIssue(ErrGenderUnexpected, gender)
 			}
 			break
 		} else {
@@ -139,7 +458,7 @@ func checkGenderSender(k string, v string, lang string) (res string, err error)
 	}
 
 	if len(l) > 0 && total != 1 { // If we have not found exactly 1 match when there are genders
-		res = fmt.Sprintf("Error with gender form - expected %s", list)
+		res = formatIssue(ErrGenderCount, list)
 	}
 
 	return res, err
@@ -147,16 +466,21 @@ func checkGenderSender(k string, v string, lang string) (res string, err error)
 
 // checkGenderReceiver()
 //
-// Check gender syntax in a receiver token value. Needs 1 of each tag for that language.
+// Check gender syntax in a receiver token value. Needs 1 of each tag for
+// that language. By default the first gender tag must sit at the beginning
+// of the value; set the language's `strictGenderOrder` config flag to false
+// (or call SetGenderOrderStrict(false)) to accept it anywhere instead, for
+// translators who reorder gender tags for grammatical reasons.
 // 	Input:
 //		- token name
 //		- token value
 //		- Language name
+//		- vf: the VDFFile instance whose genderOrderStrict setting applies
 // 	Output:
 //		- issue == nil if no syntax issue
 //		- err
 //
-func checkGenderReceiver(k string, v string, lang string) (res string, err error) {
+func checkGenderReceiver(k string, v string, lang string, vf *VDFFile) (res string, err error) {
 	l, err := conf.GetGenders(lang)
 	if err != nil {
 		return res, err
@@ -176,9 +500,9 @@ func checkGenderReceiver(k string, v string, lang string) (res string, err error
 
 		if ok := strings.Contains(list, gender); (ct != 1 || !ok) && (ct != 0 || ok) { // bad syntax cases
 			if len(list) > 0 {
-				res = fmt.Sprintf("Error with gender form: %s - expected one of each: %s", gender, list)
+				res = formatIssue(ErrGenderTag, gender, list)
 			} else {
-				res = fmt.Sprintf("Error with gender form: %s - no gender expected", gender)
+				res = formatIssue(ErrGenderUnexpected, gender)
 			}
 			break
 		} else { // (ct == 1 && ok) || (ct ==0 && !ok)
@@ -192,11 +516,11 @@ func checkGenderReceiver(k string, v string, lang string) (res string, err error
 	}
 
 	if total != len(l) { // If we don't have one of each -> syntax problem
-		res = fmt.Sprintf("Error with gender form - expected %s", list)
+		res = formatIssue(ErrGenderCount, list)
 	}
 
-	if len(l) > 1 && minIdx > 0 { // The 1st gender tag needs to be at idx 0 otherwise syntax err
-		res = fmt.Sprintf("Error with gender form - the first gender tag should be at the begining of the string. Found at position %d", minIdx)
+	if vf.genderOrderStrict(lang) && len(l) > 1 && minIdx > 0 { // The 1st gender tag needs to be at idx 0 otherwise syntax err
+		res = formatIssue(ErrGenderOrder, minIdx)
 	}
 
 	return res, err
@@ -223,7 +547,7 @@ func checkGenderSenderPlural(k string, v string, lang string) (res string, err e
 		return res, err
 	}
 
-	nbPluralExpected, err := conf.GetPlural(lang) // Get the number of plurals
+	nbPluralExpected, err := pluralFormCount(lang) // Get the number of plurals (CLDR or legacy)
 	if err != nil {
 		return res, err
 	}
@@ -233,7 +557,7 @@ func checkGenderSenderPlural(k string, v string, lang string) (res string, err e
 		nbPluralExpected-- // e.g. 2 form plural -> 1 separator
 
 		if ct := strings.Count(v, pluralTag); ct != nbPluralExpected {
-			res = fmt.Sprintf("Error with gender/plural form: found %d plural forms, while expecting %d separated wiht a  plural tag.", ct+1, nbPluralExpected+1)
+			res = formatIssue(ErrGenderPluralSeparator, ct+1, nbPluralExpected+1)
 			return res, err // Syntax issue detected
 		}
 	} else {
@@ -247,7 +571,7 @@ func checkGenderSenderPlural(k string, v string, lang string) (res string, err e
 
 		for _, gender := range genderTags {
 			if ct := strings.Count(v, gender); ct > 0 && !strings.Contains(list, gender) {
-				res = fmt.Sprintf("Error with gender/plural form: this tag was unexpected %s", gender)
+				res = formatIssue(ErrGenderPluralTag, gender)
 				break
 			} else {
 				pluralCount += ct
@@ -255,7 +579,7 @@ func checkGenderSenderPlural(k string, v string, lang string) (res string, err e
 		}
 
 		if pluralCount != nbPluralExpected { // If incorrect number of plural forms ->  error
-			res = fmt.Sprintf("Error with gender/plural forms - counted %d while expecting %d", pluralCount, nbPluralExpected)
+			res = formatIssue(ErrGenderPluralCount, pluralCount, nbPluralExpected)
 		}
 	}
 	return res, err
@@ -266,39 +590,67 @@ func checkGenderSenderPlural(k string, v string, lang string) (res string, err e
 // Check gender syntax in a receiver token value with plural.
 // Each gender list must be repeated as many time as there are plurals for the language.
 // If there are no genders but plurals (e.g. schinese) plurals are separated with the plural tag.
+// By default each plural block's gender tags must additionally appear in
+// the language's canonical order; set the language's `strictGenderOrder`
+// config flag to false (or call SetGenderOrderStrict(false)) to accept
+// them in any order within the block instead, for translators who reorder
+// gender tags for grammatical reasons. Either way the blocks themselves
+// must stay contiguous and non-overlapping.
 // 	Input:
 //		- token name
 //		- token value
 //		- Language name
+//		- vf: the VDFFile instance whose genderOrderStrict setting applies
 // 	Output:
 //		- issue	== nil if no syntax issue
 //		- err	!= nil is processing error
 //
 // E.g. "Valve_TestPluralGenders_Adjective1:gp" "#|m|#peu Commun#|f|#peu Commune#|m|#peu Communs#|f|#peu Communes"
 //
-func checkGenderReceiverPlural(k string, v string, lang string) (res string, err error) {
+func checkGenderReceiverPlural(k string, v string, lang string, vf *VDFFile) (res string, err error) {
 	lgGenderTags, err := conf.GetGenders(lang) // Get the list of gender tags
 	if err != nil {
 		return res, err // Processing error
 	}
 
+	nbPluralExpected, err := pluralFormCount(lang) // Get the number of plurals (CLDR or legacy)
+	if err != nil {
+		return res, err // Processing error
+	}
+
+	return matchGenderPluralBlocks(v, lgGenderTags, nbPluralExpected, vf.genderOrderStrict(lang)), nil
+}
+
+// matchGenderPluralBlocks()
+//
+// Core syntax check behind checkGenderReceiverPlural, split out so it can be
+// exercised without a config.Config: given the token value and the already
+// resolved gender tags/plural-form count for the language, verify that v is
+// organised in nbPluralExpected contiguous, non-overlapping blocks, each
+// containing exactly one of each tag in lgGenderTags. When strict is false
+// the tags within a block may appear in any order; when strict is true they
+// must additionally appear in the order given by lgGenderTags.
+// 	Input:
+//		- token value
+//		- lgGenderTags: gender tags expected for the language
+//		- nbPluralExpected: number of plural forms expected for the language
+//		- strict: require lgGenderTags' order within each block
+// 	Output:
+//		- issue == "" if no syntax issue
+//
+func matchGenderPluralBlocks(v string, lgGenderTags []string, nbPluralExpected int, strict bool) (res string) {
 	var list string // Convert slice to a single string
 	for _, val := range lgGenderTags {
 		list += (val + ",")
 	}
 
-	nbPluralExpected, err := conf.GetPlural(lang) // Get the number of plurals
-	if err != nil {
-		return res, err // Processing error
-	}
-
 	if nbPluralExpected > 0 && len(lgGenderTags) == 0 {
 		// Exception: if plurals but no gender: form separator is the one used for plurals
 		nbPluralExpected-- // e.g. 2 form plural -> 1 separator
 
 		if ct := strings.Count(v, pluralTag); ct != nbPluralExpected {
-			res = fmt.Sprintf("Error with gender/plural form: found %d plural forms, while expecting %d separated wiht a  plural tag.", ct+1, nbPluralExpected+1)
-			return res, err // Syntax issue detected
+			res = formatIssue(ErrGenderPluralSeparator, ct+1, nbPluralExpected+1)
+			return res // Syntax issue detected
 		}
 
 	} else {
@@ -316,11 +668,11 @@ func checkGenderReceiverPlural(k string, v string, lang string) (res string, err
 			if ok := strings.Contains(list, gender); (ct != nbPluralExpected || !ok) && (ct != 0 || ok) {
 				// bad syntax cases: wrong tag present or correct tag but wrong number of instances
 				if len(list) > 0 {
-					res = fmt.Sprintf("Error with gender/plural form: %s - found %d plural forms while expecting %d of each gender group: %s", ct, gender, nbPluralExpected, list)
+					res = formatIssue(ErrGenderPluralTagCount, gender, ct, nbPluralExpected, list)
 				} else {
-					res = fmt.Sprintf("Error with gender/plural form: %s - no gender expected", gender) // No gender expected but found gender tags...
+					res = formatIssue(ErrGenderPluralUnexpected, gender) // No gender expected but found gender tags...
 				}
-				return res, err // Syntax issue detected
+				return res // Syntax issue detected
 			} else {
 				if ok {
 					// If tag valid for this language
@@ -345,8 +697,26 @@ func checkGenderReceiverPlural(k string, v string, lang string) (res string, err
 			for g := 1; g <= len(lgGenderTags); g++ {
 				if arrayIdx[p][g] < arrayIdx[p-1][len(lgGenderTags)+1] {
 					// Error order incorrect. Provides pointer to where the error is.
-					res = fmt.Sprintf("Error with gender/plural form: incorrect order plural form: %d, gender tag: %s", p, lgGenderTags[g-1])
-					return res, err // Syntax issue detected
+					res = formatIssue(ErrGenderPluralOrder, p, lgGenderTags[g-1])
+					return res // Syntax issue detected
+				}
+				if strict && g > 1 {
+					// Strict mode: beyond the check above, lgGenderTags[g-2] must also come
+					// before lgGenderTags[g-1] within block p. Columns follow genderTags'
+					// order, not lgGenderTags', so look up where each of the two tags sits.
+					prev, cur := 0, 0
+					for c := 1; c <= len(lgGenderTags); c++ {
+						if strings.HasPrefix(v[arrayIdx[p][c]:], lgGenderTags[g-2]) {
+							prev = arrayIdx[p][c]
+						} else if strings.HasPrefix(v[arrayIdx[p][c]:], lgGenderTags[g-1]) {
+							cur = arrayIdx[p][c]
+						}
+					}
+					if cur < prev {
+						// Tag present and block contiguous, but out of the language's canonical order.
+						res = formatIssue(ErrGenderPluralOrder, p, lgGenderTags[g-1])
+						return res // Syntax issue detected
+					}
 				}
 				if arrayIdx[p][g] > arrayIdx[p][len(lgGenderTags)+1] {
 					arrayIdx[p][len(lgGenderTags)+1] = arrayIdx[p][g] // keep track of highest index
@@ -354,7 +724,7 @@ func checkGenderReceiverPlural(k string, v string, lang string) (res string, err
 			}
 		}
 	}
-	return res, err
+	return res
 }
 
 // FilterPlrGdr()
@@ -370,8 +740,12 @@ func (v *VDFFile) FilterPlrGdr(in []string) (out []string) {
 
 	var isKeyPlrExtForm = regexp.MustCompile(`:p\{[a-zA-Z_\d:]+\}$`).MatchString // capture the 'p:{value_name}' form
 
+	st := v.state()
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+
 	for _, tkn := range in {
-		for sufx, _ := range m_pluralGender {
+		for sufx := range st.suffixes {
 			if strings.HasSuffix(tkn, sufx) || isKeyPlrExtForm(tkn) {
 				out = append(out, tkn)
 				break
@@ -419,14 +793,17 @@ func (v *VDFFile) CheckNonPlrlGdr(key string, val string) (issue string, err err
 func (v *VDFFile) CheckPlrlGendrTokenVal(token string, val string, language string) (issue string, err error) {
 	v.log(fmt.Sprintf("CheckPlrlGendrTokenVal(%s, %s, %s)", token, val, language))
 
-	// Capture tag (:p, :n, :g, :gp, etc.) and call the right function to check syntax
-	if capturedTag := regexp.MustCompile(`(:[png]{1,2})(?:\{[a-zA-Z_\d:]+\})?$`).FindStringSubmatch(token); len(capturedTag) > 1 {
+	st := v.state()
+	st.mu.RLock()
+	defer st.mu.RUnlock()
 
-		if f, ok := m_pluralGender[capturedTag[1]]; ok {
-			issue, err = f.(func(string, string, string) (string, error))(token, val, language) // Check syntax
-			// bOK,bArrayRes := record.fctOpen.(func (string) (bool,[]byte))(openingTag)
+	// Capture tag (:p, :n, :g, :gp, or a registered one) and call the right function to check syntax
+	if capturedTag := st.re.FindStringSubmatch(token); len(capturedTag) > 1 {
+
+		if f, ok := st.suffixes[capturedTag[1]]; ok {
+			issue, err = f(token, val, language) // Check syntax
 		}
 	}
-	
+
 	return issue, err
 }
@@ -0,0 +1,142 @@
+package vdfloc
+
+import "testing"
+
+func TestCheckPluralCLDRExplicitTagsInOrder(t *testing.T) {
+	categories := []string{"one", "few", "many", "other"}
+	v := "#|one|#un truc#|few|#des trucs#|many|#plein de trucs#|other|#des trucs"
+
+	res, err := checkPluralCLDR(v, categories)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res != "" {
+		t.Fatalf("got issue %q, want none", res)
+	}
+}
+
+func TestCheckPluralCLDRExplicitTagsWrongOrder(t *testing.T) {
+	categories := []string{"one", "few", "many", "other"}
+	v := "#|one|#un truc#|many|#plein de trucs#|few|#des trucs#|other|#des trucs"
+
+	res, err := checkPluralCLDR(v, categories)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res == "" {
+		t.Fatalf("got no issue, want one reporting the wrong category order")
+	}
+}
+
+func TestCheckPluralCLDRExplicitTagsWrongCount(t *testing.T) {
+	categories := []string{"one", "few", "many", "other"}
+	v := "#|one|#un truc#|other|#des trucs"
+
+	res, err := checkPluralCLDR(v, categories)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res == "" {
+		t.Fatalf("got no issue, want one reporting the wrong number of categories")
+	}
+}
+
+func TestCheckPluralCLDRFallsBackToSeparatorCount(t *testing.T) {
+	categories := []string{"one", "few", "many", "other"}
+	// No explicit "#|category|#" markers: falls back to counting the plain
+	// plural-tag separators, same as the legacy integer form.
+	v := "un truc#|#des trucs#|#plein de trucs#|#des trucs"
+
+	res, err := checkPluralCLDR(v, categories)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res != "" {
+		t.Fatalf("got issue %q, want none (3 separators for 4 categories)", res)
+	}
+}
+
+func TestCheckPluralCLDRFallsBackToSeparatorCountMismatch(t *testing.T) {
+	categories := []string{"one", "few", "many", "other"}
+	v := "un truc#|#des trucs"
+
+	res, err := checkPluralCLDR(v, categories)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res == "" {
+		t.Fatalf("got no issue, want one reporting the wrong separator count")
+	}
+}
+
+func TestMatchGenderPluralBlocksAllowsPerBlockReordering(t *testing.T) {
+	tags := []string{"#|m|#", "#|f|#"}
+	// Block 1: m then f. Block 2: f then m - reordered, but still one of
+	// each tag per contiguous, non-overlapping block.
+	v := "#|m|#A#|f|#B#|f|#C#|m|#D"
+
+	if res := matchGenderPluralBlocks(v, tags, 2, false); res != "" {
+		t.Fatalf("got issue %q, want none (intra-block order is free)", res)
+	}
+}
+
+func TestMatchGenderPluralBlocksRejectsCrossBlockInterleaving(t *testing.T) {
+	tags := []string{"#|m|#", "#|f|#"}
+	// Both "m" tags before both "f" tags: right count of each, but not
+	// grouped into 2 contiguous blocks of one-of-each.
+	v := "#|m|#A#|m|#B#|f|#C#|f|#D"
+
+	if res := matchGenderPluralBlocks(v, tags, 2, false); res == "" {
+		t.Fatalf("got no issue, want one reporting the incorrect block order")
+	}
+}
+
+func TestMatchGenderPluralBlocksRejectsWrongTagCount(t *testing.T) {
+	tags := []string{"#|m|#", "#|f|#"}
+	// Only one "#|f|#" for 2 expected plural forms.
+	v := "#|m|#A#|f|#B#|m|#C"
+
+	if res := matchGenderPluralBlocks(v, tags, 2, false); res == "" {
+		t.Fatalf("got no issue, want one reporting the wrong tag count")
+	}
+}
+
+func TestMatchGenderPluralBlocksStrictRejectsPerBlockReordering(t *testing.T) {
+	tags := []string{"#|m|#", "#|f|#"}
+	// Same value TestMatchGenderPluralBlocksAllowsPerBlockReordering accepts
+	// (block 2 is "f" then "m", reordered) - strict mode requires lgGenderTags'
+	// order within each block, so this must now be rejected.
+	v := "#|m|#A#|f|#B#|f|#C#|m|#D"
+
+	if res := matchGenderPluralBlocks(v, tags, 2, true); res == "" {
+		t.Fatalf("got no issue, want one reporting the wrong in-block order (strict mode)")
+	}
+}
+
+func TestRegisterTokenSuffixIsPerInstance(t *testing.T) {
+	v1, v2 := new(VDFFile), new(VDFFile)
+
+	custom := func(key, val, lang string) (string, error) { return "", nil }
+	if err := v1.RegisterTokenSuffix(":case", custom); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := v1.FilterPlrGdr([]string{"Token:case"})
+	if len(out) != 1 {
+		t.Fatalf("got %v, want v1 to recognise :case after registering it", out)
+	}
+
+	out = v2.FilterPlrGdr([]string{"Token:case"})
+	if len(out) != 0 {
+		t.Fatalf("got %v, want v2 unaffected by v1's registration", out)
+	}
+}
+
+func TestMatchGenderPluralBlocksStrictAcceptsCanonicalOrder(t *testing.T) {
+	tags := []string{"#|m|#", "#|f|#"}
+	v := "#|m|#A#|f|#B#|m|#C#|f|#D"
+
+	if res := matchGenderPluralBlocks(v, tags, 2, true); res != "" {
+		t.Fatalf("got issue %q, want none (both blocks already in canonical order)", res)
+	}
+}
@@ -1,6 +1,8 @@
 package vdfloc
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
 	"errors"
 	"io"
@@ -26,6 +28,178 @@ var (
 
 const utf8ProbeLen = 4 * 32 * 1024 // probe length: if this length utf8 then the rest of the file is utf8
 
+// utf8ReplacementChar is the UTF-8 encoding of U+FFFD, the rune x/text
+// decoders substitute for a malformed input sequence.
+var utf8ReplacementChar = []byte{0xEF, 0xBF, 0xBD}
+
+// DecodeError reports a malformed byte sequence found while decoding a file
+// in strict mode (UTFReaderOptions.Strict).
+//
+// Known limitation: detection works by scanning the decoder's output for a
+// literal U+FFFD. Source content that legitimately contains that rune (e.g.
+// a file that already uses it as a display placeholder) is indistinguishable
+// from a substitution and will be reported as a decode error.
+type DecodeError struct {
+	Offset   int64  // byte offset in the source where the offending sequence starts, counted after any BOM (see DetectedEncoding.BOMLen)
+	Encoding string // detected/declared encoding being decoded
+	Bytes    []byte // offending byte(s)
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("invalid %s sequence at byte offset %d: % x", e.Encoding, e.Offset, e.Bytes)
+}
+
+// strictDecoder wraps a decoding transform.Transformer and turns any
+// replacement rune (U+FFFD) it emits into a DecodeError instead of letting
+// it through silently.
+//
+// unitWidth, when > 0, is the fixed number of source bytes that produce one
+// decoded rune (4 for UTF-32, 2 for UTF-16 once unpaired surrogates have
+// already been rejected by utf16SurrogateValidator; a valid surrogate pair
+// counts as two units): it lets Transform translate the
+// rune position of the replacement character back into an exact source byte
+// offset/range. For a variable-width source encoding (the generic htmlindex
+// path - shift_jis, gb18030, ...) unitWidth is 0 and x/text gives us no
+// rune-to-source-byte mapping, so Offset/Bytes only pin down the chunk this
+// Transform call was handed rather than the exact offending byte(s).
+type strictDecoder struct {
+	inner     transform.Transformer
+	encoding  string
+	unitWidth int
+	offset    int64
+}
+
+func (d *strictDecoder) Reset() { d.inner.Reset(); d.offset = 0 }
+
+func (d *strictDecoder) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	nDst, nSrc, err = d.inner.Transform(dst, src, atEOF)
+
+	if de, ok := err.(*DecodeError); ok {
+		// Raised by a wrapped validator at src[nSrc:], with an offset relative
+		// to this call's src: make it relative to the whole source.
+		de.Offset = d.offset + int64(nSrc)
+	}
+
+	if idx := bytes.Index(dst[:nDst], utf8ReplacementChar); idx != -1 {
+		offset := d.offset
+		window := append([]byte{}, src[:nSrc]...)
+
+		if d.unitWidth > 0 {
+			// Fixed-width source: each rune before idx in dst took unitWidth
+			// source bytes (twice that for a UTF-16 surrogate pair, i.e. a
+			// rune above U+FFFF), and the replacement itself took unitWidth.
+			start := 0
+			for _, r := range string(dst[:idx]) {
+				start += d.unitWidth
+				if d.unitWidth == 2 && r > 0xFFFF {
+					start += d.unitWidth
+				}
+			}
+			end := start + d.unitWidth
+			if end > len(window) {
+				end = len(window)
+			}
+			if start < len(window) {
+				offset += int64(start)
+				window = window[start:end]
+			}
+		}
+
+		return 0, 0, &DecodeError{Offset: offset, Encoding: d.encoding, Bytes: window}
+	}
+
+	d.offset += int64(nSrc)
+	return nDst, nSrc, err
+}
+
+// stricten wraps t so a replacement rune in its output becomes a DecodeError.
+// unitWidth is the fixed source bytes-per-rune for t's encoding, or 0 if t
+// decodes a variable-width encoding (see strictDecoder).
+func stricten(t transform.Transformer, encodingName string, unitWidth int) transform.Transformer {
+	return &strictDecoder{inner: t, encoding: encodingName, unitWidth: unitWidth}
+}
+
+// utf16SurrogateValidator is a transform.Transformer operating on raw UTF-16
+// code units. It rejects unpaired surrogates (a high surrogate D800-DBFF not
+// followed by a low surrogate DC00-DFFF, or a lone low surrogate) with a
+// DecodeError instead of letting the downstream decoder turn them into
+// replacement characters.
+type utf16SurrogateValidator struct {
+	bigEndian bool
+}
+
+func (utf16SurrogateValidator) Reset() {}
+
+func (v utf16SurrogateValidator) unit(b []byte) uint16 {
+	if v.bigEndian {
+		return uint16(b[0])<<8 | uint16(b[1])
+	}
+	return uint16(b[1])<<8 | uint16(b[0])
+}
+
+func (v utf16SurrogateValidator) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc+1 < len(src) {
+		if nDst+2 > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+
+		unit := v.unit(src[nSrc:])
+
+		switch {
+		case unit >= 0xD800 && unit <= 0xDBFF: // high surrogate: must be followed by a low surrogate
+			if nSrc+3 >= len(src) {
+				if !atEOF {
+					return nDst, nSrc, transform.ErrShortSrc
+				}
+				return nDst, nSrc, &DecodeError{Offset: int64(nSrc), Encoding: "UTF16", Bytes: append([]byte{}, src[nSrc:]...)}
+			}
+			low := v.unit(src[nSrc+2:])
+			if low < 0xDC00 || low > 0xDFFF {
+				return nDst, nSrc, &DecodeError{Offset: int64(nSrc), Encoding: "UTF16", Bytes: append([]byte{}, src[nSrc:nSrc+2]...)}
+			}
+			if nDst+4 > len(dst) {
+				return nDst, nSrc, transform.ErrShortDst
+			}
+			copy(dst[nDst:], src[nSrc:nSrc+4])
+			nDst += 4
+			nSrc += 4
+		case unit >= 0xDC00 && unit <= 0xDFFF: // unpaired low surrogate
+			return nDst, nSrc, &DecodeError{Offset: int64(nSrc), Encoding: "UTF16", Bytes: append([]byte{}, src[nSrc:nSrc+2]...)}
+		default:
+			copy(dst[nDst:], src[nSrc:nSrc+2])
+			nDst += 2
+			nSrc += 2
+		}
+	}
+	if nSrc < len(src) {
+		if !atEOF {
+			return nDst, nSrc, transform.ErrShortSrc
+		}
+		return nDst, nSrc, &DecodeError{Offset: int64(nSrc), Encoding: "UTF16", Bytes: append([]byte{}, src[nSrc:]...)}
+	}
+	return nDst, nSrc, nil
+}
+
+// UTFReaderOptions carries options for UTFReaderWithOptions.
+type UTFReaderOptions struct {
+	// Strict rejects malformed input instead of silently falling back or
+	// substituting replacement characters: the UTF-8 probe fails outright on
+	// a broken sequence, UTF-16 unpaired surrogates are rejected, and any
+	// other decoder that would emit U+FFFD returns a *DecodeError instead.
+	Strict bool
+}
+
+// DetectedEncoding describes the encoding a UTFReader* call detected (or was
+// given), so a downstream writer can reproduce the original framing
+// faithfully instead of working off a bare name.
+type DetectedEncoding struct {
+	Name   string // e.g. "UTF8", "UTF8BOM", "UTF16LE", "windows-1252"
+	HasBOM bool   // the source started with a byte-order mark
+	BOMLen int    // length, in bytes, of that BOM (0 if HasBOM is false)
+}
+
+func (d DetectedEncoding) String() string { return d.Name }
+
 // https://ompp.sourceforge.io/src/go.openmpp.org/ompp/helper/utf8.go Utf8Reader
 // UTFReader returns a reader to transform file content to utf-8.
 //
@@ -38,89 +212,119 @@ const utf8ProbeLen = 4 * 32 * 1024 // probe length: if this length utf8 then the
 // If encodingName explicitly specified then it is used to convert file content to string.
 // If none of above then assume default encoding: "windows-1252" on Windows and "utf-8" on Linux.
 func UTFReader(f *os.File, encodingName string) (r io.Reader, encodingFound string, err error) {
+	return UTFReaderWithOptions(f, encodingName, UTFReaderOptions{})
+}
 
-	// validate parameters
+// UTFReaderWithOptions()
+// Thin wrapper around UTFReaderFromReader kept for callers that still pass
+// an *os.File and expect the historical bare-string encoding name. See
+// UTFReaderFromReader for the real implementation and UTFReaderOptions.Strict
+// for malformed-input handling.
+func UTFReaderWithOptions(f *os.File, encodingName string, opts UTFReaderOptions) (r io.Reader, encodingFound string, err error) {
 	if f == nil {
 		return nil, encodingFound, errors.New("invalid (nil) source file")
 	}
 
-	// detect BOM
-	bom := make([]byte, utf8.UTFMax)
+	r, detected, err := UTFReaderFromReader(f, encodingName, opts)
+	return r, detected.Name, err
+}
 
-	nBom, err := f.Read(bom)
-	if err != nil {
-		if nBom == 0 && err == io.EOF { // empty file: retrun source file as is
-			return f, encodingFound, nil
-		}
-		return nil, encodingFound, errors.New("file read error: " + err.Error())
-	}
+// UTFReaderFromReader()
+// Same as UTFReader, but works off any io.Reader (a pipe, an http.Response.Body,
+// a gzip reader, an in-memory buffer, ...) instead of requiring an *os.File:
+// BOM/encoding detection is done by peeking ahead through a bufio.Reader, not
+// by seeking back to the start.
+func UTFReaderFromReader(r io.Reader, encodingName string, opts UTFReaderOptions) (out io.Reader, detected DetectedEncoding, err error) {
 
-	// if utf-8 BOM then skip it and return source file
-	if nBom >= len(Utf8bom) && bom[0] == Utf8bom[0] && bom[1] == Utf8bom[1] && bom[2] == Utf8bom[2] {
-		if _, err := f.Seek(int64(len(Utf8bom)), 0); err != nil {
-			return nil, encodingFound, errors.New("file seek error: " + err.Error())
-		}
-		return f, "UTF8BOM", nil
+	if r == nil {
+		return nil, detected, errors.New("invalid (nil) source reader")
 	}
 
-	// move back to the file begining to use BOM, if present
-	if _, err := f.Seek(0, 0); err != nil {
-		return nil, encodingFound, errors.New("file seek error (moving back) " + err.Error())
-	}
+	br := bufio.NewReaderSize(r, utf8ProbeLen+utf8.UTFMax)
+
+	// detect BOM: peek, don't consume yet
+	bom, _ := br.Peek(utf8.UTFMax) // best-effort: fewer bytes near EOF is fine
+
+	switch {
+	case len(bom) >= len(Utf8bom) && bytes.Equal(bom[:len(Utf8bom)], Utf8bom):
+		br.Discard(len(Utf8bom))
+		return br, DetectedEncoding{Name: "UTF8BOM", HasBOM: true, BOMLen: len(Utf8bom)}, nil
 
 	// ambiguous utf-16LE and utf32-LE detection: assume utf-32LE because 00 00 is very unlikely in text file
-	if nBom >= len(Utf32LEbom) && bom[0] == Utf32LEbom[0] && bom[1] == Utf32LEbom[1] && bom[2] == Utf32LEbom[2] && bom[3] == Utf32LEbom[3] {
-		return transform.NewReader(f, utf32.UTF32(utf32.LittleEndian, utf32.UseBOM).NewDecoder()), "UTF32LE", nil
-	}
-	if nBom >= len(Utf32BEbom) && bom[0] == Utf32BEbom[0] && bom[1] == Utf32BEbom[1] && bom[2] == Utf32BEbom[2] && bom[3] == Utf32BEbom[3] {
-		return transform.NewReader(f, utf32.UTF32(utf32.BigEndian, utf32.UseBOM).NewDecoder()), "UTF32BE", nil
-	}
-	if nBom >= len(Utf16LEbom) && bom[0] == Utf16LEbom[0] && bom[1] == Utf16LEbom[1] {
-		return transform.NewReader(f, unicode.BOMOverride(encoding.Nop.NewDecoder())), "UTF16LE", nil
-	}
-	if nBom >= len(Utf16BEbom) && bom[0] == Utf16BEbom[0] && bom[1] == Utf16BEbom[1] {
-		return transform.NewReader(f, unicode.BOMOverride(encoding.Nop.NewDecoder())), "UTF16BE", nil
+	case len(bom) >= len(Utf32LEbom) && bytes.Equal(bom[:len(Utf32LEbom)], Utf32LEbom):
+		br.Discard(len(Utf32LEbom))
+		detected = DetectedEncoding{Name: "UTF32LE", HasBOM: true, BOMLen: len(Utf32LEbom)}
+		var dec transform.Transformer = utf32.UTF32(utf32.LittleEndian, utf32.IgnoreBOM).NewDecoder()
+		if opts.Strict {
+			dec = stricten(dec, detected.Name, 4)
+		}
+		return transform.NewReader(br, dec), detected, nil
+
+	case len(bom) >= len(Utf32BEbom) && bytes.Equal(bom[:len(Utf32BEbom)], Utf32BEbom):
+		br.Discard(len(Utf32BEbom))
+		detected = DetectedEncoding{Name: "UTF32BE", HasBOM: true, BOMLen: len(Utf32BEbom)}
+		var dec transform.Transformer = utf32.UTF32(utf32.BigEndian, utf32.IgnoreBOM).NewDecoder()
+		if opts.Strict {
+			dec = stricten(dec, detected.Name, 4)
+		}
+		return transform.NewReader(br, dec), detected, nil
+
+	case len(bom) >= len(Utf16LEbom) && bytes.Equal(bom[:len(Utf16LEbom)], Utf16LEbom):
+		br.Discard(len(Utf16LEbom))
+		detected = DetectedEncoding{Name: "UTF16LE", HasBOM: true, BOMLen: len(Utf16LEbom)}
+		var dec transform.Transformer = unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewDecoder()
+		if opts.Strict {
+			dec = stricten(transform.Chain(utf16SurrogateValidator{bigEndian: false}, dec), detected.Name, 2)
+		}
+		return transform.NewReader(br, dec), detected, nil
+
+	case len(bom) >= len(Utf16BEbom) && bytes.Equal(bom[:len(Utf16BEbom)], Utf16BEbom):
+		br.Discard(len(Utf16BEbom))
+		detected = DetectedEncoding{Name: "UTF16BE", HasBOM: true, BOMLen: len(Utf16BEbom)}
+		var dec transform.Transformer = unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM).NewDecoder()
+		if opts.Strict {
+			dec = stricten(transform.Chain(utf16SurrogateValidator{bigEndian: true}, dec), detected.Name, 2)
+		}
+		return transform.NewReader(br, dec), detected, nil
 	}
 	// no BOM detected
 
-	// encoding not specified then probe file to check is it utf-8
+	// encoding not specified then probe the stream to check is it already utf-8
 	if encodingName == "" {
 
-		// read probe bytes from the file
-		buf := make([]byte, utf8ProbeLen)
-		nProbe, err := f.Read(buf)
-		if err != nil {
-			if nProbe == 0 && err == io.EOF { // empty file: retrun source file as is
-				return f, "UTF32LE", nil
-			}
-			return nil, encodingFound, errors.New("file read error: " + err.Error())
-		}
+		// peek probe bytes, without consuming them: the probed bytes still
+		// need to flow through to whatever reader we return below.
+		probe, _ := br.Peek(utf8ProbeLen) // best-effort: fewer bytes if the stream is shorter
 
 		// check if all runes are utf-8
 		nPos := 0
-		for nPos < nProbe {
-			r, n := utf8.DecodeRune(buf)
+		rest := probe
+		for nPos < len(probe) {
+			r, n := utf8.DecodeRune(rest)
 			if n <= 0 || r == utf8.RuneError { // if eof or not utf-8 rune
 				break
 			}
 			nPos += n
-			buf = buf[n:]
+			rest = rest[n:]
 		}
 
-		// move back to the file begining
-		if _, err := f.Seek(0, 0); err != nil {
-			return nil, encodingFound, errors.New("file seek error: " + err.Error())
+		// stream is utf-8 (or empty) if:
+		// all runes are utf-8 and probe is shorter than the max probe size, or probe size excceeds it
+		if nPos >= len(probe) || nPos >= utf8ProbeLen-utf8.UTFMax {
+			return br, DetectedEncoding{Name: "UTF8"}, nil
 		}
 
-		// file is utf-8 if:
-		// all runes are utf-8 and file size less than max probe size or file size excceeds probe size
-		if nPos >= nProbe || nPos >= utf8ProbeLen-utf8.UTFMax {
-			return f, "UTF8", nil // utf-8 file: return source file reader
+		if opts.Strict {
+			// a non-ASCII run failed to decode as utf-8: in strict mode we
+			// don't silently fall back to another encoding, we fail.
+			end := nPos + utf8.UTFMax
+			if end > len(probe) {
+				end = len(probe)
+			}
+			return nil, detected, &DecodeError{Offset: int64(nPos), Encoding: "UTF8", Bytes: append([]byte{}, probe[nPos:end]...)}
 		}
-	}
 
-	// if encoding is not explicitly specified then use UTF8
-	if encodingName == "" {
+		// if encoding is not explicitly specified then use UTF8
 		//if runtime.GOOS == "windows" {
 		//	encodingName = "windows-1252"
 		//} else {
@@ -129,42 +333,110 @@ func UTFReader(f *os.File, encodingName string) (r io.Reader, encodingFound stri
 	}
 
 	// get encoding by name
-	enc, err := htmlindex.Get(encodingName)
-	encodingFound = encodingName
-	if err != nil {
-		return nil, encodingFound, errors.New("invalid encoding: " + encodingName + " " + err.Error())
+	enc, herr := htmlindex.Get(encodingName)
+	if herr != nil {
+		return nil, DetectedEncoding{Name: encodingName}, errors.New("invalid encoding: " + encodingName + " " + herr.Error())
+	}
+	detected = DetectedEncoding{Name: encodingName}
+
+	var dec transform.Transformer = unicode.BOMOverride(enc.NewDecoder())
+	if opts.Strict {
+		dec = stricten(dec, detected.Name, 0)
 	}
 
-	return transform.NewReader(f, unicode.BOMOverride(enc.NewDecoder())), encodingFound, nil
+	return transform.NewReader(br, dec), detected, nil
 }
 
-// UTF8Conv()
-// Convert a UTF8 buffer to UTF16BE or LE
-//	encodingName can be UTF16LE, UTF16BE, UTF8BOM, UTF8
-// 	if encoding name is UTF8 or UTF8BOM returns buf
+// SupportedWriteEncodings()
 //
-func UTF8Conv(buf []byte, encodingName string) (out []byte, err error) {
+// Well-known encoding names handled natively. A BOM is written for them only
+// when the name ends in "bom" (e.g. "utf8bom", "utf16lebom") or
+// UTF8ConvOptions.WithBOM is set. Any other name recognised by golang.org/x/text's
+// htmlindex (e.g. "windows-1252", "shift_jis", "gb18030") also works with
+// UTF8Conv and NewUTFConvWriter, just without an implied BOM.
+func SupportedWriteEncodings() []string {
+	return []string{"utf8", "utf8bom", "utf16le", "utf16be"}
+}
+
+// UTF8ConvOptions carries options for UTF8Conv and NewUTFConvWriter.
+type UTF8ConvOptions struct {
+	WithBOM bool // force emitting a BOM, even if encodingName doesn't carry a "bom" hint
+}
+
+// resolveWriteEncoding()
+// Resolve an encoding name to an encoding.Encoding plus the BOM bytes to
+// write ahead of the encoded content, if any.
+//	encodingName can be UTF8, UTF8BOM, UTF16LE, UTF16BE, or any name known to htmlindex.
+// 	enc == nil means passthrough: write buf as-is.
+//
+func resolveWriteEncoding(encodingName string, opts UTF8ConvOptions) (enc encoding.Encoding, bom []byte, err error) {
+
+	name := strings.ToLower(encodingName)
+	withBOM := opts.WithBOM || strings.Contains(name, "bom")
+	name = strings.TrimSuffix(name, "bom")
 
-	var enc encoding.Encoding
-	var bom []byte
-	
-	switch strings.ToLower(encodingName) {
-	case "utf8":
-		return buf, nil
-	case "utf8bom":
-		bom = []byte{0xEF, 0xBB, 0xBF}  // printout a BOM
-		return buf, nil
+	switch name {
+	case "utf8", "":
+		if withBOM {
+			bom = Utf8bom
+		}
+		return nil, bom, nil
 	case "utf16le":
-		enc = unicode.UTF16(unicode.LittleEndian, unicode.UseBOM )
+		if withBOM {
+			bom = Utf16LEbom
+		}
+		return unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM), bom, nil
 	case "utf16be":
-		enc = unicode.UTF16(unicode.BigEndian, unicode.UseBOM )
+		if withBOM {
+			bom = Utf16BEbom
+		}
+		return unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM), bom, nil
 	}
 
-	var utfEncoder *encoding.Encoder
-	utfEncoder = enc.NewEncoder()
-	out, err = utfEncoder.Bytes(buf)
-	
-	return append(bom,out...), nil
+	enc, herr := htmlindex.Get(name)
+	if herr != nil {
+		return nil, nil, fmt.Errorf("invalid encoding: %s - %s", encodingName, herr)
+	}
+	if withBOM {
+		// Legacy/CJK encodings resolved through htmlindex (windows-1252,
+		// shift_jis, gb18030, ...) have no standard byte-order mark, unlike
+		// utf8/utf16le/utf16be above - fail instead of silently writing the
+		// content as if WithBOM had been honored.
+		return nil, nil, fmt.Errorf("encoding %q has no byte-order mark - WithBOM is only supported for utf8, utf16le and utf16be", encodingName)
+	}
+	return enc, nil, nil
+}
+
+// UTF8Conv()
+// Convert a UTF8 buffer to the target encoding.
+//	encodingName can be UTF16LE, UTF16BE, UTF8BOM, UTF8, or any name known to
+//	htmlindex (see SupportedWriteEncodings and resolveWriteEncoding).
+// 	if encoding name is UTF8 or UTF8BOM no transcoding occurs, only the BOM changes
+//
+func UTF8Conv(buf []byte, encodingName string) (out []byte, err error) {
+	return UTF8ConvWithOptions(buf, encodingName, UTF8ConvOptions{})
+}
+
+// UTF8ConvWithOptions()
+// Same as UTF8Conv but lets the caller force a BOM via UTF8ConvOptions.WithBOM.
+//
+func UTF8ConvWithOptions(buf []byte, encodingName string, opts UTF8ConvOptions) (out []byte, err error) {
+
+	enc, bom, err := resolveWriteEncoding(encodingName, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if enc == nil {
+		return append(bom, buf...), nil
+	}
+
+	out, err = enc.NewEncoder().Bytes(buf)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert %v - %v", buf, err)
+	}
+
+	return append(bom, out...), nil
 }
 
 type UTF8Enc struct {
@@ -177,6 +449,14 @@ type UTF8Enc struct {
 // - In: File (nil for stdout) and encoding
 // - Returns instance and error code
 func NewUTFConvWriter(f *os.File, encodingName string) (u *UTF8Enc, err error) {
+	return NewUTFConvWriterWithOptions(f, encodingName, UTF8ConvOptions{})
+}
+
+// NewUTFConvWriterWithOptions()
+// Same as NewUTFConvWriter but lets the caller force a BOM via UTF8ConvOptions.WithBOM.
+// - In: File (nil for stdout), encoding and options
+// - Returns instance and error code
+func NewUTFConvWriterWithOptions(f *os.File, encodingName string, opts UTF8ConvOptions) (u *UTF8Enc, err error) {
 
 	u = &UTF8Enc{} // Create instance
 
@@ -186,22 +466,15 @@ func NewUTFConvWriter(f *os.File, encodingName string) (u *UTF8Enc, err error) {
 	}
 	u.f = f
 	u.ioName = f.Name()
+	u.encoding = encodingName
 
-	var enc encoding.Encoding
-
-	// fmt.Printf("enc=%s, outname=%s\n",encodingName, u.ioName)
+	enc, bom, err := resolveWriteEncoding(encodingName, opts)
+	if err != nil {
+		return nil, err
+	}
 
-	switch strings.ToLower(encodingName) {
-	case "utf8":
-	case "utf8bom":
-		f.Write([]byte{0xEF, 0xBB, 0xBF})		// printout a BOM
-	case "utf16le":
-		f.Write([]byte{0xFF, 0xFE})  			// printout a BOM
-		enc = unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM )
-	case "utf16be":
-		f.Write([]byte{0xFE, 0xFF})			// printout a BOM
-		enc = unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM )
-	default:
+	if len(bom) > 0 {
+		f.Write(bom) // printout a BOM
 	}
 
 	if enc != nil {
@@ -213,8 +486,8 @@ func NewUTFConvWriter(f *os.File, encodingName string) (u *UTF8Enc, err error) {
 
 
 // UTF8ConvWriter()
-// Convert a UTF8 buffer to UTF16BE or LE
-//	encodingName can be UTF16LE, UTF16BE, UTF8BOM, UTF8
+// Convert a UTF8 buffer to the target encoding.
+//	encodingName can be UTF16LE, UTF16BE, UTF8BOM, UTF8, or any name known to htmlindex.
 // 	if encoding name is UTF8 or UTF8BOM skip the convertion
 // Returns the number of bytes writen
 //
@@ -232,7 +505,7 @@ func (u *UTF8Enc) Write(buf []byte) (n int, err error) {
 	if err != nil {
 		return 0, fmt.Errorf("Unable to write: %v", err)
 	}
-	return n, nil	
+	return n, nil
 }
 
 // Close()
@@ -0,0 +1,221 @@
+package vdfloc
+
+import (
+	"io"
+	"testing"
+
+	"golang.org/x/text/encoding/unicode/utf32"
+	"golang.org/x/text/transform"
+)
+
+func TestUTF16SurrogateValidatorAcceptsValidPair(t *testing.T) {
+	// U+1F600 (GRINNING FACE) little-endian: high surrogate D83D, low surrogate DE00.
+	src := []byte{0x3D, 0xD8, 0x00, 0xDE}
+	v := utf16SurrogateValidator{bigEndian: false}
+
+	dst := make([]byte, 16)
+	nDst, nSrc, err := v.Transform(dst, src, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if nSrc != len(src) || nDst != len(src) {
+		t.Fatalf("got nDst=%d nSrc=%d, want nDst=%d nSrc=%d", nDst, nSrc, len(src), len(src))
+	}
+}
+
+func TestUTF16SurrogateValidatorRejectsUnpairedHigh(t *testing.T) {
+	// High surrogate D83D followed by a plain BMP unit (0041 "A"), not a low surrogate.
+	src := []byte{0x3D, 0xD8, 0x41, 0x00}
+	v := utf16SurrogateValidator{bigEndian: false}
+
+	dst := make([]byte, 16)
+	_, nSrc, err := v.Transform(dst, src, true)
+
+	de, ok := err.(*DecodeError)
+	if !ok {
+		t.Fatalf("got err=%v, want *DecodeError", err)
+	}
+	if nSrc != 0 {
+		t.Fatalf("got nSrc=%d, want 0 (nothing consumed before the error)", nSrc)
+	}
+	if de.Offset != 0 {
+		t.Fatalf("got Offset=%d, want 0", de.Offset)
+	}
+}
+
+func TestUTF16SurrogateValidatorRejectsLoneLowSurrogate(t *testing.T) {
+	// A BMP unit followed by an unpaired low surrogate DC00.
+	src := []byte{0x41, 0x00, 0x00, 0xDC}
+	v := utf16SurrogateValidator{bigEndian: false}
+
+	dst := make([]byte, 16)
+	_, nSrc, err := v.Transform(dst, src, true)
+
+	de, ok := err.(*DecodeError)
+	if !ok {
+		t.Fatalf("got err=%v, want *DecodeError", err)
+	}
+	if nSrc != 2 {
+		t.Fatalf("got nSrc=%d, want 2 (the valid BMP unit was consumed first)", nSrc)
+	}
+	if de.Offset != 2 {
+		t.Fatalf("got Offset=%d, want 2", de.Offset)
+	}
+}
+
+func TestUTF16SurrogateValidatorRejectsTruncatedTrailingByteAtEOF(t *testing.T) {
+	// A single dangling trailing byte with no more data coming: not even a
+	// whole code unit, let alone a full surrogate pair.
+	src := []byte{0x3D}
+	v := utf16SurrogateValidator{bigEndian: false}
+
+	dst := make([]byte, 16)
+	_, nSrc, err := v.Transform(dst, src, true)
+
+	de, ok := err.(*DecodeError)
+	if !ok {
+		t.Fatalf("got err=%v, want *DecodeError", err)
+	}
+	if nSrc != 0 {
+		t.Fatalf("got nSrc=%d, want 0 (nothing consumed before the error)", nSrc)
+	}
+	if de.Offset != 0 {
+		t.Fatalf("got Offset=%d, want 0", de.Offset)
+	}
+	if len(de.Bytes) != 1 {
+		t.Fatalf("got %d offending bytes, want 1 (the dangling trailing byte)", len(de.Bytes))
+	}
+}
+
+func TestUTF16SurrogateValidatorAsksForMoreAtShortSrc(t *testing.T) {
+	// A lone high surrogate with no following unit yet and more data expected.
+	src := []byte{0x3D, 0xD8}
+	v := utf16SurrogateValidator{bigEndian: false}
+
+	dst := make([]byte, 16)
+	_, _, err := v.Transform(dst, src, false)
+	if err != transform.ErrShortSrc {
+		t.Fatalf("got err=%v, want transform.ErrShortSrc", err)
+	}
+}
+
+func TestStrictDecoderReportsExactOffsetForFixedWidthEncoding(t *testing.T) {
+	// Two valid UTF-32LE runes ("A", "B") followed by a code unit past the
+	// Unicode range (0x00110000), which the decoder turns into U+FFFD.
+	src := []byte{
+		0x41, 0x00, 0x00, 0x00,
+		0x42, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x11, 0x00,
+	}
+
+	dec := stricten(utf32.UTF32(utf32.LittleEndian, utf32.IgnoreBOM).NewDecoder(), "UTF32LE", 4)
+
+	dst := make([]byte, 64)
+	_, _, err := dec.Transform(dst, src, true)
+
+	de, ok := err.(*DecodeError)
+	if !ok {
+		t.Fatalf("got err=%v, want *DecodeError", err)
+	}
+	if de.Offset != 8 {
+		t.Fatalf("got Offset=%d, want 8 (after the two valid 4-byte runes)", de.Offset)
+	}
+	if len(de.Bytes) != 4 {
+		t.Fatalf("got %d offending bytes, want 4 (the single malformed code unit, not the whole chunk)", len(de.Bytes))
+	}
+}
+
+// singleByteReader hands back at most one byte per Read, to exercise
+// UTFReaderFromReader's BOM/UTF-8 probing against a genuinely chunked
+// io.Reader (a pipe, a network connection, ...) instead of a reader that
+// satisfies a whole Peek() in one shot.
+type singleByteReader struct {
+	data []byte
+}
+
+func (r *singleByteReader) Read(p []byte) (n int, err error) {
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+	p[0] = r.data[0]
+	r.data = r.data[1:]
+	return 1, nil
+}
+
+func TestUTFReaderFromReaderDetectsUTF8BOMOverChunkedReader(t *testing.T) {
+	src := append(append([]byte{}, Utf8bom...), []byte("hello")...)
+
+	out, detected, err := UTFReaderFromReader(&singleByteReader{data: src}, "", UTFReaderOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if detected.Name != "UTF8BOM" || !detected.HasBOM {
+		t.Fatalf("got detected=%+v, want UTF8BOM with HasBOM", detected)
+	}
+
+	got, err := io.ReadAll(out)
+	if err != nil {
+		t.Fatalf("unexpected read error: %v", err)
+	}
+	if string(got) != "hello" {
+		t.Fatalf("got %q, want %q (BOM stripped)", got, "hello")
+	}
+}
+
+func TestUTFReaderFromReaderDetectsUTF16LEBOMOverChunkedReader(t *testing.T) {
+	src := append(append([]byte{}, Utf16LEbom...), []byte{0x41, 0x00, 0x42, 0x00}...) // "AB"
+
+	out, detected, err := UTFReaderFromReader(&singleByteReader{data: src}, "", UTFReaderOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if detected.Name != "UTF16LE" || !detected.HasBOM {
+		t.Fatalf("got detected=%+v, want UTF16LE with HasBOM", detected)
+	}
+
+	got, err := io.ReadAll(out)
+	if err != nil {
+		t.Fatalf("unexpected read error: %v", err)
+	}
+	if string(got) != "AB" {
+		t.Fatalf("got %q, want %q", got, "AB")
+	}
+}
+
+func TestUTFReaderFromReaderProbesPlainUTF8OverChunkedReader(t *testing.T) {
+	src := []byte("plain ascii, no BOM here")
+
+	out, detected, err := UTFReaderFromReader(&singleByteReader{data: src}, "", UTFReaderOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if detected.Name != "UTF8" || detected.HasBOM {
+		t.Fatalf("got detected=%+v, want UTF8 without a BOM", detected)
+	}
+
+	got, err := io.ReadAll(out)
+	if err != nil {
+		t.Fatalf("unexpected read error: %v", err)
+	}
+	if string(got) != string(src) {
+		t.Fatalf("got %q, want %q", got, src)
+	}
+}
+
+func TestUTFReaderFromReaderEmptyStreamDetectsUTF8(t *testing.T) {
+	out, detected, err := UTFReaderFromReader(&singleByteReader{}, "", UTFReaderOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if detected.Name != "UTF8" || detected.HasBOM {
+		t.Fatalf("got detected=%+v, want bare UTF8 for an empty stream", detected)
+	}
+
+	got, err := io.ReadAll(out)
+	if err != nil {
+		t.Fatalf("unexpected read error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("got %q, want empty output", got)
+	}
+}